@@ -15,15 +15,24 @@ import (
 
 func main() {
 	var (
-		mockOpts mock.Options
-		logLevel string
+		mockOpts       mock.Options
+		logLevel       string
+		sourceArg      string
+		modeArg        string
+		partitionByArg string
 	)
 
-	flag.StringVar(&mockOpts.BaseDir, "dir", "", "Base directory for the module (required)")
+	flag.StringVar(&mockOpts.BaseDir, "dir", "", "Base directory for the module (required unless -source or -mode=replay is given)")
 	flag.StringVar(&mockOpts.OutputDir, "output-dir", "", "Output directory for the generated file, if not provided will write to stdout")
 	flag.StringVar(&mockOpts.PackageName, "package-name", "awsmocked", "Name of the generated package")
-	flag.StringVar(&mockOpts.SearchPackages, "packages", "", "Comma seperated list of packages to search (required)")
+	flag.StringVar(&mockOpts.SearchPackages, "packages", "", "Comma seperated list of packages to search (required unless -source or -mode=replay is given)")
 	flag.BoolVar(&mockOpts.ClientDefault, "default-panic", false, "Add a panic for Operations that are not mocked")
+	flag.StringVar(&mockOpts.FixturesDir, "fixtures-dir", "", "Directory of '<service>/<Operation>.json' fixtures, or pkg/record recordings, to load as canned responses")
+	flag.BoolVar(&mockOpts.EmitVerification, "emit-verification", false, "Emit a call recorder and Verify<Service>(t, client).<Operation>(matcher) assertion API")
+	flag.StringVar(&sourceArg, "source", "", "Comma seperated list of .go files to parse directly, instead of loading -packages")
+	flag.StringVar(&modeArg, "mode", "generate", "'generate' (default, scan -packages or -source) or 'replay' (build the mock package from -fixtures-dir alone, with no source to scan)")
+	flag.StringVar(&partitionByArg, "partition-by", "", "Comma separated partition dimensions (region, account) for an On<Dimension> per-partition response builder")
+	flag.BoolVar(&mockOpts.IncludeMethodSets, "include-method-sets", false, "Also mock every method of any AWS client type found in a struct field or user-defined interface, not just ones actually called")
 
 	flag.StringVar(&logLevel, "log-level", "info", "Set the log level [debug, info, warn, error]")
 
@@ -33,8 +42,30 @@ func main() {
 		Level: logLevelFromArg(logLevel),
 	})))
 
-	if mockOpts.SearchPackages == "" || mockOpts.BaseDir == "" {
-		fmt.Println("'packages' and 'dir' are required flags")
+	if sourceArg != "" {
+		mockOpts.SourceFiles = strings.Split(sourceArg, ",")
+	}
+
+	if partitionByArg != "" {
+		mockOpts.PartitionBy = strings.Split(partitionByArg, ",")
+	}
+
+	switch modeArg {
+	case "replay":
+		mockOpts.ReplayFixtures = true
+		if mockOpts.FixturesDir == "" {
+			fmt.Println("'fixtures-dir' is required when -mode=replay")
+			flag.Usage()
+			os.Exit(1)
+		}
+	case "generate":
+		if mockOpts.SourceFiles == nil && (mockOpts.SearchPackages == "" || mockOpts.BaseDir == "") {
+			fmt.Println("'packages' and 'dir' are required flags, unless 'source' is given")
+			flag.Usage()
+			os.Exit(1)
+		}
+	default:
+		fmt.Printf("unknown -mode %q, must be 'generate' or 'replay'\n", modeArg)
 		flag.Usage()
 		os.Exit(1)
 	}
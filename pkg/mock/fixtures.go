@@ -0,0 +1,112 @@
+package mock
+
+import (
+	"bytes"
+	log "log/slog"
+	"os"
+	"text/template"
+
+	"golang.org/x/tools/imports"
+)
+
+// fixturesTemplate emits a companion "<PackageName>_fixtures.go" file that teaches
+// every generated "<Service>Client" to load canned responses from JSON fixture files
+// instead of requiring hand-written Go literals. Fixture files use the AWS SDK v2 JSON
+// shape, so traffic recorded against a real service can be dropped in unmodified.
+//
+// Two fixture layouts are understood, tried in this order: a "<service>/<Operation>/"
+// directory of "<hash>.json" recordings, as written by pkg/record, and a single
+// legacy "<service>/<Operation>.json" file. A "<hash>.input.json" file recorded
+// alongside a "<hash>.json" one is skipped; nothing currently reads it back.
+const fixturesTemplate = `// Code generated by aws-mocker. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+{{range .Middlewares}}{{$pkg := .}}
+// Load{{ToTitle $pkg.Name}}Fixtures reads "{{$pkg.Name}}/<Operation>/<hash>.json" recordings, or a
+// legacy "{{$pkg.Name}}/<Operation>.json" file, out of dir and appends them, in file order, to
+// each operation's response queue. A missing fixture is not an error: operations without one
+// simply fall back to any matcher responses configured in code, then to ClientDefault or a zero
+// value.
+func (c *{{ToTitle $pkg.Name}}Client) Load{{ToTitle $pkg.Name}}Fixtures(dir string) error {
+	if dir == "" {
+		return nil
+	}
+{{range .FuncSigs}}
+	if entries, err := os.ReadDir(path.Join(dir, "{{$pkg.Name}}", "{{.FuncName}}")); err == nil {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+		for _, entry := range entries {
+			if !strings.HasSuffix(entry.Name(), ".json") || strings.HasSuffix(entry.Name(), ".input.json") {
+				continue
+			}
+
+			data, err := os.ReadFile(path.Join(dir, "{{$pkg.Name}}", "{{.FuncName}}", entry.Name()))
+			if err != nil {
+				return fmt.Errorf("{{$pkg.Name}}.{{.FuncName}} fixture: %w", err)
+			}
+			var out {{$pkg.Name}}.{{.Return}}
+			if err := json.Unmarshal(data, &out); err != nil {
+				return fmt.Errorf("{{$pkg.Name}}.{{.FuncName}} fixture: %w", err)
+			}
+			c.{{.FuncName}}Responses = append(c.{{.FuncName}}Responses, struct {
+				Match func(*{{$pkg.Name}}.{{.Input}}) bool
+				Out   *{{$pkg.Name}}.{{.Return}}
+				Err   error
+			}{Out: &out})
+		}
+	} else if data, err := os.ReadFile(path.Join(dir, "{{$pkg.Name}}", "{{.FuncName}}.json")); err == nil {
+		var out {{$pkg.Name}}.{{.Return}}
+		if err := json.Unmarshal(data, &out); err != nil {
+			return fmt.Errorf("{{$pkg.Name}}.{{.FuncName}} fixture: %w", err)
+		}
+		c.{{.FuncName}}Responses = append(c.{{.FuncName}}Responses, struct {
+			Match func(*{{$pkg.Name}}.{{.Input}}) bool
+			Out   *{{$pkg.Name}}.{{.Return}}
+			Err   error
+		}{Out: &out})
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("{{$pkg.Name}}.{{.FuncName}} fixture: %w", err)
+	}
+{{end}}
+	return nil
+}
+{{end}}`
+
+// writeFixturesLoader renders fixturesTemplate and writes it out as a companion file
+// alongside the main generated mock.
+func writeFixturesLoader(opts *Options, data TemplateData) error {
+	tmpl, err := template.New("fixtures").Funcs(templateFuncs()).Parse(fixturesTemplate)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, data); err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	formatted, err := imports.Process("filename", buf.Bytes(), &imports.Options{
+		TabWidth:  4,
+		TabIndent: true,
+		Comments:  true,
+		Fragment:  true,
+	})
+	if err != nil {
+		log.Debug(buf.String())
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	_, err = companionWriter(opts, "_fixtures").Write(formatted)
+	return err
+}
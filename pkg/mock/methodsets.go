@@ -0,0 +1,101 @@
+package mock
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// collectMethodSets drives Options.IncludeMethodSets: it walks every declaration in
+// pkg.TypesInfo.Defs, rather than only call sites in TypesInfo.Uses, so a service
+// reached solely through a struct field or a user-defined interface still has its
+// full method set mocked.
+func collectMethodSets(pkg *packages.Package, resses map[string]PackageInfo) {
+	for _, obj := range pkg.TypesInfo.Defs {
+		if obj == nil {
+			continue
+		}
+
+		switch o := obj.(type) {
+		case *types.Var:
+			// Struct fields and other variable declarations, e.g. `DDB *dynamodb.Client`.
+			addClientMethodSet(o.Type(), resses)
+		case *types.TypeName:
+			// Named interface declarations, e.g. `type DDBAPI interface { ... }`.
+			if iface, ok := o.Type().Underlying().(*types.Interface); ok {
+				addInterfaceOperations(iface, resses)
+			}
+		}
+	}
+}
+
+// addClientMethodSet adds every method of t, if t (after unwrapping one level of
+// pointer) is a named type from a package matching filter, the same way source.go's
+// loadFromSourceFiles does for a parsed field type.
+func addClientMethodSet(t types.Type, resses map[string]PackageInfo) {
+	named, ok := clientType(t)
+	if !ok || named.Obj().Pkg() == nil || !filter.MatchString(named.Obj().Pkg().Path()) {
+		return
+	}
+
+	addMethodSet(resses, named)
+}
+
+// addInterfaceOperations looks for user-defined interfaces such as
+//
+//	type DDBAPI interface {
+//		ListTables(ctx context.Context, params *dynamodb.ListTablesInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error)
+//	}
+//
+// that a mocked service may only ever be called through, and, once iface is
+// confirmed to name an AWS SDK v2 service by a parameter type matching filter,
+// enumerates that service's whole client type the same way addClientMethodSet does
+// for a struct field, rather than settling for only the subset of methods iface
+// itself happens to declare.
+func addInterfaceOperations(iface *types.Interface, resses map[string]PackageInfo) {
+	for i := 0; i < iface.NumMethods(); i++ {
+		f := iface.Method(i)
+
+		sig, ok := f.Type().(*types.Signature)
+		if !ok || sig.Params().Len() < 2 || sig.Results().Len() < 1 {
+			continue
+		}
+
+		named, ok := clientType(sig.Params().At(1).Type())
+		if !ok || named.Obj().Pkg() == nil || !filter.MatchString(named.Obj().Pkg().Path()) {
+			continue
+		}
+
+		client := serviceClientType(named.Obj().Pkg())
+		if client == nil {
+			// The service package doesn't export a conventional Client type to
+			// enumerate; fall back to just the method iface itself declares.
+			addFuncSig(resses, named.Obj().Pkg().Path(), named.Obj().Pkg().Name(), FuncSig{
+				FuncName: f.Name(),
+				Input:    lastTypeName(sig.Params().At(1).Type().String()),
+				Return:   lastTypeName(sig.Results().At(0).Type().String()),
+			})
+			continue
+		}
+
+		addMethodSet(resses, client)
+	}
+}
+
+// serviceClientType looks up pkg's exported "Client" type, the name every AWS SDK v2
+// service package uses for its client (e.g. *dynamodb.Client), so a reference found
+// via a user-defined interface can still be expanded to the real client's full
+// method set.
+func serviceClientType(pkg *types.Package) *types.Named {
+	tn, ok := pkg.Scope().Lookup("Client").(*types.TypeName)
+	if !ok {
+		return nil
+	}
+
+	named, ok := tn.Type().(*types.Named)
+	if !ok {
+		return nil
+	}
+
+	return named
+}
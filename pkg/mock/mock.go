@@ -9,6 +9,7 @@ import (
 	"io"
 	log "log/slog"
 	"os"
+	"path"
 	"regexp"
 	"slices"
 	"sort"
@@ -20,6 +21,8 @@ import (
 	"golang.org/x/text/language"
 	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/imports"
+
+	"github.com/dramich/aws-mocker/pkg/writer"
 )
 
 type Options struct {
@@ -30,6 +33,47 @@ type Options struct {
 
 	ClientDefault bool
 	Writer        io.Writer
+
+	// FixturesDir, when set, causes generated clients to load canned responses from
+	// "<FixturesDir>/<service>/<Operation>.json" files, or "<FixturesDir>/<service>/
+	// <Operation>/<hash>.json" recordings made with pkg/record, at construction time.
+	// See fixtures.go for the companion file this emits.
+	FixturesDir string
+
+	// EmitVerification adds a call recorder to every generated client and a fluent
+	// Verify<Service>(t, client).<Operation>(matcher) assertion API over it. See
+	// verify_template.go.
+	EmitVerification bool
+
+	// SourceFiles, when set, switches generation to source mode: the listed .go files
+	// are parsed directly with go/parser and go/types instead of loading
+	// SearchPackages with golang.org/x/tools/go/packages, so generation still works
+	// when the caller's module does not build. See source.go.
+	SourceFiles []string
+
+	// ReplayFixtures, when set, switches generation to replay mode: instead of
+	// scanning any source, FuncSigs are discovered from the layout of FixturesDir
+	// itself, which must already hold recordings made with pkg/record. This lets a
+	// mock package be regenerated from a previous recording alone, with no compiled
+	// module, -packages or -source input at all. See replay.go.
+	ReplayFixtures bool
+
+	// PartitionBy adds an On<Dimension>(key) fluent builder to every generated
+	// client for each dimension listed, e.g. []string{"region", "account"}, so a
+	// single mock instance can return different responses depending on the
+	// partition key (see pkg/mock/partition) a call's context carries. See
+	// partition_template.go.
+	PartitionBy []string
+
+	// IncludeMethodSets extends loadFromPackages beyond call-site discovery: every
+	// struct field, variable and user-defined interface in scope is also inspected,
+	// and if it names (or, for an interface, its methods reference) an AWS SDK v2
+	// client type, every method of that type is added as a FuncSig. This mocks a
+	// service's full surface even when only a few of its operations are actually
+	// called in the scanned source, e.g. a service reached only through a wrapper
+	// struct such as `type App struct{ DDB *dynamodb.Client }`. Has no effect in
+	// source mode, which already does full method-set discovery; see source.go.
+	IncludeMethodSets bool
 }
 
 type PackageInfo struct {
@@ -40,6 +84,7 @@ type PackageInfo struct {
 
 type FuncSig struct {
 	FuncName string
+	Input    string
 	Return   string
 }
 
@@ -47,6 +92,10 @@ type TemplateData struct {
 	ClientDefault bool
 	PackageName   string
 	Middlewares   []PackageInfo
+
+	FixturesDir      string
+	EmitVerification bool
+	PartitionBy      []string
 }
 
 // This is hardcoded to only look for the services clients
@@ -61,61 +110,14 @@ var serviceNames = map[string]string{
 }
 
 func Run(opts *Options) error {
-	resses := make(map[string]PackageInfo)
-
-	conf := &packages.Config{Dir: opts.BaseDir, Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedTypes | packages.NeedTypesInfo}
-	pkgs, err := packages.Load(conf, strings.Split(opts.SearchPackages, ",")...)
-	if err != nil {
-		log.Error(err.Error())
-		os.Exit(1)
-	}
-
-	for _, pkg := range pkgs {
-		if len(pkg.Errors) != 0 {
-			fmt.Println(pkg.Errors)
-			os.Exit(1)
-		}
-		for _, obj := range pkg.TypesInfo.Uses {
-			// filter out all the func types
-			if f, ok := obj.(*types.Func); ok {
-				// some (error).Error() objects do not have a Pkg. Filter these out so .Pkg().Path() does not panic
-				if obj.Pkg() == nil {
-					continue
-				}
-
-				// filter out only funcs where package matches
-				if filter.MatchString(obj.Pkg().Path()) {
-					// If parent is nil it's a method
-					if f.Parent() == nil {
-						log.Debug("func", obj.Name(), obj.Pkg().Name(), obj.Pkg().Path(), pkg.Fset.Position(obj.Pos()))
-
-						sig, sigOK := f.Type().(*types.Signature)
-						if !sigOK {
-							log.Error("failed to convert", "func", f.Name())
-							os.Exit(1)
-						}
-
-						funcSig := FuncSig{
-							FuncName: f.Name(),
-							Return:   strings.Split(sig.Results().At(0).Type().String(), ".")[2],
-						}
-
-						if p, pkgOK := resses[f.Pkg().Path()]; pkgOK {
-							if !slices.Contains(p.FuncSigs, funcSig) {
-								p.FuncSigs = append(p.FuncSigs, funcSig)
-								resses[f.Pkg().Path()] = p
-							}
-						} else {
-							resses[f.Pkg().Path()] = PackageInfo{
-								Name:     f.Pkg().Name(),
-								Path:     f.Pkg().Path(),
-								FuncSigs: []FuncSig{funcSig},
-							}
-						}
-					}
-				}
-			}
-		}
+	var resses map[string]PackageInfo
+	switch {
+	case opts.ReplayFixtures:
+		resses = loadFromFixtures(opts.FixturesDir)
+	case len(opts.SourceFiles) > 0:
+		resses = loadFromSourceFiles(opts.SourceFiles)
+	default:
+		resses = loadFromPackages(opts)
 	}
 
 	// The template writer is useful to see what packages are found when debugging issues and only prints when debug is enabled
@@ -129,6 +131,10 @@ func Run(opts *Options) error {
 		ClientDefault: opts.ClientDefault,
 		PackageName:   opts.PackageName,
 		Middlewares:   sorted,
+		FixturesDir:   opts.FixturesDir,
+
+		EmitVerification: opts.EmitVerification,
+		PartitionBy:      opts.PartitionBy,
 	}
 
 	tmpl, err := template.New("mock").Funcs(templateFuncs()).Parse(fullTemplate)
@@ -156,9 +162,38 @@ func Run(opts *Options) error {
 		os.Exit(1)
 	}
 
-	_, err = opts.Writer.Write(formatted)
+	if _, err = opts.Writer.Write(formatted); err != nil {
+		return err
+	}
 
-	return err
+	if opts.FixturesDir != "" {
+		if err := writeFixturesLoader(opts, t); err != nil {
+			return err
+		}
+	}
+
+	if opts.EmitVerification {
+		if err := writeVerification(opts, t); err != nil {
+			return err
+		}
+	}
+
+	if len(opts.PartitionBy) > 0 {
+		return writePartition(opts, t)
+	}
+
+	return nil
+}
+
+// companionWriter returns a writer for a generated file that accompanies the main
+// mock file, named "<PackageName><suffix>.go" and placed alongside it. When the
+// caller asked for stdout output there is nowhere to place a second file, so the
+// companion content is written to the same writer, after the main file.
+func companionWriter(opts *Options, suffix string) io.Writer {
+	if opts.OutputDir == "" {
+		return opts.Writer
+	}
+	return writer.New(path.Join(opts.OutputDir, opts.PackageName+suffix+".go"))
 }
 
 func templateFuncs() template.FuncMap {
@@ -181,6 +216,97 @@ func templateFuncs() template.FuncMap {
 	}
 }
 
+// loadFromPackages discovers FuncSigs by loading opts.SearchPackages with
+// golang.org/x/tools/go/packages and walking every use of a function from a package
+// matching filter. This is the default, call-site driven discovery mode; see
+// loadFromSourceFiles for the alternative used when opts.SourceFiles is set.
+func loadFromPackages(opts *Options) map[string]PackageInfo {
+	resses := make(map[string]PackageInfo)
+
+	conf := &packages.Config{Dir: opts.BaseDir, Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedTypes | packages.NeedTypesInfo}
+	pkgs, err := packages.Load(conf, strings.Split(opts.SearchPackages, ",")...)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) != 0 {
+			fmt.Println(pkg.Errors)
+			os.Exit(1)
+		}
+		for _, obj := range pkg.TypesInfo.Uses {
+			// filter out all the func types
+			if f, ok := obj.(*types.Func); ok {
+				// some (error).Error() objects do not have a Pkg. Filter these out so .Pkg().Path() does not panic
+				if obj.Pkg() == nil {
+					continue
+				}
+
+				// filter out only funcs where package matches
+				if filter.MatchString(obj.Pkg().Path()) {
+					// If parent is nil it's a method
+					if f.Parent() == nil {
+						log.Debug("func", obj.Name(), obj.Pkg().Name(), obj.Pkg().Path(), pkg.Fset.Position(obj.Pos()))
+
+						sig, sigOK := f.Type().(*types.Signature)
+						if !sigOK {
+							log.Error("failed to convert", "func", f.Name())
+							os.Exit(1)
+						}
+
+						// Client methods are all of the shape (ctx, params, optFns...) (out, error),
+						// so the input type is always the second parameter.
+						if sig.Params().Len() < 2 {
+							log.Error("unexpected signature", "func", f.Name())
+							os.Exit(1)
+						}
+
+						funcSig := FuncSig{
+							FuncName: f.Name(),
+							Input:    lastTypeName(sig.Params().At(1).Type().String()),
+							Return:   lastTypeName(sig.Results().At(0).Type().String()),
+						}
+
+						addFuncSig(resses, f.Pkg().Path(), f.Pkg().Name(), funcSig)
+					}
+				}
+			}
+		}
+
+		if opts.IncludeMethodSets {
+			collectMethodSets(pkg, resses)
+		}
+	}
+
+	return resses
+}
+
+// addFuncSig records funcSig against the package at path, creating the PackageInfo
+// entry on first sight and de-duplicating on repeat sightings of the same operation.
+func addFuncSig(resses map[string]PackageInfo, path, name string, funcSig FuncSig) {
+	if p, ok := resses[path]; ok {
+		if !slices.Contains(p.FuncSigs, funcSig) {
+			p.FuncSigs = append(p.FuncSigs, funcSig)
+			resses[path] = p
+		}
+		return
+	}
+
+	resses[path] = PackageInfo{
+		Name:     name,
+		Path:     path,
+		FuncSigs: []FuncSig{funcSig},
+	}
+}
+
+// lastTypeName trims a qualified type string such as
+// "*github.com/aws/aws-sdk-go-v2/service/dynamodb.ListTablesOutput" down to
+// "ListTablesOutput".
+func lastTypeName(s string) string {
+	return strings.Split(s, ".")[2]
+}
+
 // sortPackages sorts the package based on the path, funcs based on their name
 // and converts to a slice for the template
 func sortPackages(in map[string]PackageInfo) []PackageInfo {
@@ -0,0 +1,91 @@
+// Package partition is the runtime support for mock.Options.PartitionBy: extracting
+// a partition key (region, account, or an explicit assumed-role tag) from the AWS
+// SDK v2 middleware context that a generated client's stub consults before falling
+// back to its matcher/queue responses.
+package partition
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+)
+
+// Key is a single partition dimension and the value a client was invoked, or a
+// response was registered, with.
+type Key struct {
+	Dim   string
+	Value string
+}
+
+// Keys is an ordered set of partition dimension/value pairs. It is both the map key
+// a generated client registers per-partition responses under and the value an
+// OnRegion/OnAccount-style builder accumulates as it's chained.
+type Keys []Key
+
+// With returns a copy of ks with dim=value set, replacing any existing value for
+// the same dim, kept sorted by dim so String is stable regardless of call order.
+func (ks Keys) With(dim, value string) Keys {
+	out := make(Keys, 0, len(ks)+1)
+	for _, k := range ks {
+		if k.Dim != dim {
+			out = append(out, k)
+		}
+	}
+	out = append(out, Key{Dim: dim, Value: value})
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Dim < out[j].Dim })
+	return out
+}
+
+// String renders ks as a stable map key, e.g. "account=111111111111,region=us-west-2".
+func (ks Keys) String() string {
+	parts := make([]string, len(ks))
+	for i, k := range ks {
+		parts[i] = k.Dim + "=" + k.Value
+	}
+	return strings.Join(parts, ",")
+}
+
+// roleKey is the type used for the context value WithRole sets, kept unexported so
+// only this package can set or read it.
+type roleKey struct{}
+
+// WithRole returns a copy of ctx tagging it with an explicit assumed-role partition
+// key, for callers that assume different roles within a single SDK Config rather
+// than switching credentials per account.
+func WithRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, roleKey{}, role)
+}
+
+// dimValue extracts dim's value from ctx, for the "region" and "account" dimensions
+// mock.Options.PartitionBy supports.
+func dimValue(ctx context.Context, dim string) string {
+	switch dim {
+	case "region":
+		return awsmiddleware.GetRegion(ctx)
+	case "account":
+		if role, ok := ctx.Value(roleKey{}).(string); ok && role != "" {
+			return role
+		}
+		// Falls back to the SigV4 signing name that AWS SDK v2 middleware carries
+		// through every call's context. That name identifies the service being
+		// called (e.g. "dynamodb"), not the account, so calls made without a
+		// WithRole tag share one account key per service.
+		return awsmiddleware.GetSigningName(ctx)
+	default:
+		return ""
+	}
+}
+
+// KeyFor extracts a Keys from ctx, one entry per dimension in dims, sorted by
+// dimension name (see With) whatever the order of dims, for a generated stub to
+// look its per-partition response set up with.
+func KeyFor(ctx context.Context, dims []string) Keys {
+	var ks Keys
+	for _, dim := range dims {
+		ks = ks.With(dim, dimValue(ctx, dim))
+	}
+	return ks
+}
@@ -0,0 +1,52 @@
+package partition
+
+import (
+	"context"
+	"testing"
+)
+
+func TestKeysWith(t *testing.T) {
+	ks := Keys{}.With("region", "us-west-2").With("account", "111111111111")
+
+	if got, want := ks.String(), "account=111111111111,region=us-west-2"; got != want {
+		t.Errorf("String() = %q, want %q (dims should sort regardless of With order)", got, want)
+	}
+
+	// Setting the same dim again should replace, not accumulate.
+	ks = ks.With("region", "eu-west-1")
+	if got, want := ks.String(), "account=111111111111,region=eu-west-1"; got != want {
+		t.Errorf("String() after replacing region = %q, want %q", got, want)
+	}
+}
+
+func TestKeysOrderIndependent(t *testing.T) {
+	a := Keys{}.With("region", "us-west-2").With("account", "111111111111")
+	b := Keys{}.With("account", "111111111111").With("region", "us-west-2")
+
+	if a.String() != b.String() {
+		t.Errorf("expected With order not to affect String(): %q != %q", a.String(), b.String())
+	}
+}
+
+func TestDimValueUnknownDim(t *testing.T) {
+	if got := dimValue(context.Background(), "bogus"); got != "" {
+		t.Errorf("dimValue for an unrecognized dimension = %q, want empty", got)
+	}
+}
+
+func TestDimValueAccountPrefersRole(t *testing.T) {
+	ctx := WithRole(context.Background(), "arn:aws:iam::111111111111:role/Example")
+
+	if got, want := dimValue(ctx, "account"), "arn:aws:iam::111111111111:role/Example"; got != want {
+		t.Errorf("dimValue(account) with a role tag = %q, want %q", got, want)
+	}
+}
+
+func TestKeyForUsesRoleTag(t *testing.T) {
+	ctx := WithRole(context.Background(), "role-a")
+
+	ks := KeyFor(ctx, []string{"account", "region"})
+	if got, want := ks.String(), "account=role-a,region="; got != want {
+		t.Errorf("KeyFor() = %q, want %q", got, want)
+	}
+}
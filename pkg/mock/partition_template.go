@@ -0,0 +1,103 @@
+package mock
+
+import (
+	"bytes"
+	"html/template"
+	log "log/slog"
+	"os"
+
+	"golang.org/x/tools/imports"
+)
+
+// partitionTemplate emits a companion "<PackageName>_partition.go" file giving each
+// generated "<Service>Client" an On<Dimension>-style fluent builder, e.g.
+// client.OnRegion("us-west-2").ListTables().Returns(out, err), for one dimension per
+// entry in Options.PartitionBy ("region", "account"). A stub whose partition key
+// (see pkg/mock/partition) has no registered response falls back to the existing
+// matcher/queue behavior.
+const partitionTemplate = `// Code generated by aws-mocker. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+	"github.com/dramich/aws-mocker/pkg/mock/partition"
+)
+{{$partitionBy := .PartitionBy}}
+{{range .Middlewares}}{{$pkg := .}}
+// {{ToTitle $pkg.Name}}Partition accumulates the partition key a {{ToTitle $pkg.Name}}Client
+// response should be registered for, or matched against, via On{{range $partitionBy}}{{ToTitle .}}/{{end}}.
+type {{ToTitle $pkg.Name}}Partition struct {
+	client *{{ToTitle $pkg.Name}}Client
+	keys   partition.Keys
+}
+{{range $partitionBy}}
+// On{{ToTitle .}} starts a partitioned response registration keyed on {{.}}=key.
+func (c *{{ToTitle $pkg.Name}}Client) On{{ToTitle .}}(key string) {{ToTitle $pkg.Name}}Partition {
+	return {{ToTitle $pkg.Name}}Partition{client: c}.On{{ToTitle .}}(key)
+}
+
+// On{{ToTitle .}} narrows p to also require {{.}}=key.
+func (p {{ToTitle $pkg.Name}}Partition) On{{ToTitle .}}(key string) {{ToTitle $pkg.Name}}Partition {
+	p.keys = p.keys.With("{{.}}", key)
+	return p
+}
+{{end}}
+{{range .FuncSigs}}
+// {{.FuncName}} returns a builder for the response {{ToTitle $pkg.Name}}Client's {{.FuncName}}
+// stub should return when called with p's partition key.
+func (p {{ToTitle $pkg.Name}}Partition) {{.FuncName}}() {{ToTitle $pkg.Name}}{{.FuncName}}Partition {
+	return {{ToTitle $pkg.Name}}{{.FuncName}}Partition{partition: p}
+}
+
+// {{ToTitle $pkg.Name}}{{.FuncName}}Partition is the terminal builder step for {{.FuncName}}, set with Returns.
+type {{ToTitle $pkg.Name}}{{.FuncName}}Partition struct {
+	partition {{ToTitle $pkg.Name}}Partition
+}
+
+// Returns registers out/err as the response to {{.FuncName}} calls whose partition key
+// (see partition.KeyFor) matches the one accumulated by p, consulted before the
+// matcher/queue responses.
+func (p {{ToTitle $pkg.Name}}{{.FuncName}}Partition) Returns(out *{{$pkg.Name}}.{{.Return}}, err error) {
+	if p.partition.client.{{.FuncName}}Partitions == nil {
+		p.partition.client.{{.FuncName}}Partitions = map[string]struct {
+			Out *{{$pkg.Name}}.{{.Return}}
+			Err error
+		}{}
+	}
+	p.partition.client.{{.FuncName}}Partitions[p.partition.keys.String()] = struct {
+		Out *{{$pkg.Name}}.{{.Return}}
+		Err error
+	}{Out: out, Err: err}
+}
+{{end}}{{end}}`
+
+// writePartition renders partitionTemplate and writes it out as a companion file
+// alongside the main generated mock.
+func writePartition(opts *Options, data TemplateData) error {
+	tmpl, err := template.New("partition").Funcs(templateFuncs()).Parse(partitionTemplate)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, data); err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	formatted, err := imports.Process("filename", buf.Bytes(), &imports.Options{
+		TabWidth:  4,
+		TabIndent: true,
+		Comments:  true,
+		Fragment:  true,
+	})
+	if err != nil {
+		log.Debug(buf.String())
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	_, err = companionWriter(opts, "_partition").Write(formatted)
+	return err
+}
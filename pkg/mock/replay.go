@@ -0,0 +1,63 @@
+package mock
+
+import (
+	log "log/slog"
+	"os"
+	"path"
+	"strings"
+)
+
+// fixturesImportPrefix is the import path every AWS SDK v2 service package lives
+// under; see filter. loadFromFixtures has no source to read a package's real import
+// path from, so it reconstructs one from the service directory name under this
+// prefix instead.
+const fixturesImportPrefix = "github.com/aws/aws-sdk-go-v2/service/"
+
+// loadFromFixtures discovers FuncSigs from the layout of dir rather than from any Go
+// source: one subdirectory per AWS service, and inside that either a legacy
+// "<Operation>.json" file (see fixtures.go) or an "<Operation>/" directory of
+// "<hash>.json" recordings made with pkg/record. This drives Options.ReplayFixtures,
+// where a mock package is regenerated straight from a previous recording with
+// nothing to scan at all.
+//
+// Since there is no type information to consult in this mode, input/output type
+// names are derived from the AWS SDK v2 naming convention (<Operation>Input /
+// <Operation>Output) rather than discovered.
+func loadFromFixtures(dir string) map[string]PackageInfo {
+	resses := make(map[string]PackageInfo)
+
+	services, err := os.ReadDir(dir)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	for _, svc := range services {
+		if !svc.IsDir() {
+			continue
+		}
+
+		operations, err := os.ReadDir(path.Join(dir, svc.Name()))
+		if err != nil {
+			log.Error(err.Error())
+			os.Exit(1)
+		}
+
+		for _, op := range operations {
+			name, isJSON := strings.CutSuffix(op.Name(), ".json")
+			if !op.IsDir() && !isJSON {
+				continue
+			}
+
+			funcSig := FuncSig{
+				FuncName: name,
+				Input:    name + "Input",
+				Return:   name + "Output",
+			}
+
+			addFuncSig(resses, fixturesImportPrefix+svc.Name(), svc.Name(), funcSig)
+		}
+	}
+
+	return resses
+}
@@ -0,0 +1,145 @@
+package mock
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	log "log/slog"
+	"os"
+)
+
+// loadFromSourceFiles parses the given .go files directly with go/parser and
+// go/types, rather than loading the caller's module with packages.Load. This mirrors
+// the "source mode" mockgen supports: it keeps working when the caller's module has
+// compile errors elsewhere, and lets a user target a specific declaration, such as
+//
+//	type Deps struct { DDB *dynamodb.Client }
+//
+// without having to call every operation in code first. Since there are no call
+// sites to walk in this mode, the field/parameter/return types referenced by the
+// parsed files are inspected instead, and every method of any AWS SDK v2 client type
+// found there is recorded.
+func loadFromSourceFiles(files []string) map[string]PackageInfo {
+	fset := token.NewFileSet()
+
+	astFiles := make([]*ast.File, 0, len(files))
+	for _, f := range files {
+		file, err := parser.ParseFile(fset, f, nil, parser.ParseComments)
+		if err != nil {
+			log.Error(err.Error())
+			os.Exit(1)
+		}
+		astFiles = append(astFiles, file)
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// Source mode exists precisely because the caller's module may not build
+		// cleanly, so type errors are expected and should not abort generation.
+		Error: func(err error) {
+			log.Debug("type check", "error", err)
+		},
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+	}
+
+	// The package name and path here are internal to this check; what matters is the
+	// named types discovered while checking, not the synthetic package itself.
+	_, _ = conf.Check("source", fset, astFiles, info)
+
+	resses := make(map[string]PackageInfo)
+	seen := make(map[string]bool)
+	for _, tv := range info.Types {
+		named, ok := clientType(tv.Type)
+		if !ok || named.Obj().Pkg() == nil || !filter.MatchString(named.Obj().Pkg().Path()) {
+			continue
+		}
+
+		if seen[named.String()] {
+			continue
+		}
+		seen[named.String()] = true
+
+		addMethodSet(resses, named)
+	}
+
+	return resses
+}
+
+// clientType unwraps a single level of pointer indirection to get at the named type
+// beneath, since AWS SDK v2 clients and client interfaces are always referenced as
+// pointers (e.g. *dynamodb.Client) or plain interface values.
+func clientType(t types.Type) (*types.Named, bool) {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	n, ok := t.(*types.Named)
+	return n, ok
+}
+
+// addMethodSet enumerates every exported operation on named that matches the
+// (ctx, params, optFns...) (out, error) client method shape, and records each as a
+// FuncSig the same way the call-site discovery path does.
+//
+// A real AWS SDK v2 client's method set also carries unexported plumbing methods
+// (e.g. dynamodb.Client.invokeOperation, addOperationXMiddlewares) that happen to
+// share the same parameter/result count as an operation method, so both the
+// exported-name check and the operationInputType check below are required, not just
+// the param/result count.
+func addMethodSet(resses map[string]PackageInfo, named *types.Named) {
+	// A pointer to an interface has an empty method set, so a named interface is
+	// enumerated as itself.
+	var recv types.Type = types.NewPointer(named)
+	if types.IsInterface(named) {
+		recv = named
+	}
+	ms := types.NewMethodSet(recv)
+	pkgPath := named.Obj().Pkg().Path()
+
+	for i := 0; i < ms.Len(); i++ {
+		f, ok := ms.At(i).Obj().(*types.Func)
+		if !ok || !token.IsExported(f.Name()) {
+			continue
+		}
+
+		sig, ok := f.Type().(*types.Signature)
+		if !ok || sig.Params().Len() < 2 || sig.Results().Len() < 1 {
+			continue
+		}
+
+		input, ok := operationInputType(sig.Params().At(1).Type(), pkgPath)
+		if !ok {
+			continue
+		}
+
+		funcSig := FuncSig{
+			FuncName: f.Name(),
+			Input:    input,
+			Return:   lastTypeName(sig.Results().At(0).Type().String()),
+		}
+
+		addFuncSig(resses, pkgPath, named.Obj().Pkg().Name(), funcSig)
+	}
+}
+
+// operationInputType confirms t is a pointer to a named type declared in pkgPath —
+// the shape of every AWS SDK v2 operation's params argument (e.g. *dynamodb.
+// ListTablesInput) — and returns its bare name if so. This is what rejects a
+// client's unexported plumbing methods, whose second parameter is something else
+// entirely (e.g. invokeOperation's opID string), from being mistaken for operations.
+func operationInputType(t types.Type, pkgPath string) (string, bool) {
+	p, ok := t.(*types.Pointer)
+	if !ok {
+		return "", false
+	}
+
+	named, ok := p.Elem().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != pkgPath {
+		return "", false
+	}
+
+	return named.Obj().Name(), true
+}
@@ -0,0 +1,97 @@
+package mock
+
+import (
+	"go/types"
+	"testing"
+)
+
+// newFakeClient builds a minimal go/types model of an AWS SDK v2 service client, shaped
+// closely enough to exercise addMethodSet's filtering: one exported operation method
+// following the (ctx, *Input, optFns...) (*Output, error) shape, alongside unexported
+// plumbing methods that a real client (e.g. dynamodb.Client's invokeOperation,
+// addOperationXMiddlewares) also carries and which share its parameter/result count
+// without being operations.
+func newFakeClient() *types.Named {
+	pkg := types.NewPackage("github.com/aws/aws-sdk-go-v2/service/fakesvc", "fakesvc")
+
+	ctxIface := types.NewInterfaceType(nil, nil)
+	anyIface := types.NewInterfaceType(nil, nil)
+	errType := types.Universe.Lookup("error").Type()
+
+	input := types.NewNamed(types.NewTypeName(0, pkg, "FooInput", nil), types.NewStruct(nil, nil), nil)
+	output := types.NewNamed(types.NewTypeName(0, pkg, "FooOutput", nil), types.NewStruct(nil, nil), nil)
+	options := types.NewNamed(types.NewTypeName(0, pkg, "Options", nil), types.NewStruct(nil, nil), nil)
+
+	client := types.NewNamed(types.NewTypeName(0, pkg, "Client", nil), types.NewStruct(nil, nil), nil)
+	recv := func() *types.Var { return types.NewVar(0, nil, "", types.NewPointer(client)) }
+
+	optFn := types.NewSignatureType(nil, nil, nil,
+		types.NewTuple(types.NewVar(0, nil, "", types.NewPointer(options))), nil, false)
+
+	// Foo(ctx context.Context, params *FooInput, optFns ...func(*Options)) (*FooOutput, error)
+	client.AddMethod(types.NewFunc(0, pkg, "Foo", types.NewSignatureType(
+		recv(), nil, nil,
+		types.NewTuple(
+			types.NewVar(0, nil, "ctx", ctxIface),
+			types.NewVar(0, nil, "params", types.NewPointer(input)),
+			types.NewVar(0, nil, "optFns", types.NewSlice(optFn)),
+		),
+		types.NewTuple(
+			types.NewVar(0, nil, "", types.NewPointer(output)),
+			types.NewVar(0, nil, "", errType),
+		),
+		true,
+	)))
+
+	// invokeOperation(ctx context.Context, opID string, params any, optFns []func(*Options)) (any, error)
+	client.AddMethod(types.NewFunc(0, pkg, "invokeOperation", types.NewSignatureType(
+		recv(), nil, nil,
+		types.NewTuple(
+			types.NewVar(0, nil, "ctx", ctxIface),
+			types.NewVar(0, nil, "opID", types.Typ[types.String]),
+			types.NewVar(0, nil, "params", anyIface),
+			types.NewVar(0, nil, "optFns", types.NewSlice(optFn)),
+		),
+		types.NewTuple(
+			types.NewVar(0, nil, "", anyIface),
+			types.NewVar(0, nil, "", errType),
+		),
+		false,
+	)))
+
+	// addOperationFooMiddlewares(stack *middleware.Stack, options Options) error
+	client.AddMethod(types.NewFunc(0, pkg, "addOperationFooMiddlewares", types.NewSignatureType(
+		recv(), nil, nil,
+		types.NewTuple(
+			types.NewVar(0, nil, "stack", anyIface),
+			types.NewVar(0, nil, "options", options),
+		),
+		types.NewTuple(
+			types.NewVar(0, nil, "", errType),
+		),
+		false,
+	)))
+
+	return client
+}
+
+// TestAddMethodSetSkipsPlumbingMethods reproduces the crash the maintainer review
+// caught: a real client's unexported plumbing methods (invokeOperation,
+// addOperation<Op>Middlewares) share addMethodSet's loose (ctx, X, optFns...)
+// (out, error) shape check, so without filtering to exported, operation-input-shaped
+// methods, lastTypeName panics on a non-qualified type string such as "string".
+func TestAddMethodSetSkipsPlumbingMethods(t *testing.T) {
+	resses := make(map[string]PackageInfo)
+
+	addMethodSet(resses, newFakeClient())
+
+	pkg, ok := resses["github.com/aws/aws-sdk-go-v2/service/fakesvc"]
+	if !ok {
+		t.Fatalf("expected a PackageInfo for fakesvc, got %+v", resses)
+	}
+
+	want := []FuncSig{{FuncName: "Foo", Input: "FooInput", Return: "FooOutput"}}
+	if len(pkg.FuncSigs) != len(want) || pkg.FuncSigs[0] != want[0] {
+		t.Errorf("FuncSigs = %+v, want %+v (plumbing methods must not appear)", pkg.FuncSigs, want)
+	}
+}
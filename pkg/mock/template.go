@@ -0,0 +1,87 @@
+package mock
+
+// fullTemplate emits the main generated mock file: one "<Service>Client" struct per
+// discovered PackageInfo, with one stub method per FuncSig matching the real client's
+// signature. Each stub consults its operation's response slot — first any matcher
+// response whose Match reports true, then the first FIFO-queued response (a response
+// with a nil Match, consumed in append order) — and only once both are exhausted
+// falls back to ClientDefault (a panic) or a zero value, in that order. The
+// "<Operation>Responses" slot this declares is what fixtures.go's loader appends to
+// and what test code sets directly.
+//
+// When EmitVerification is set, every stub also records its full (ctx, input,
+// optFns) call onto an "<Operation>Calls" recorder before consulting its responses,
+// which verify_template.go's generated Verify<Service>(t, client).<Operation>(matcher)
+// API reads back.
+//
+// When PartitionBy is set, every stub first looks its partition key (see
+// pkg/mock/partition) up in an "<Operation>Partitions" map, and only falls through
+// to the matcher/queue/default behavior above if that partition has no response
+// registered, which partition_template.go's generated On<Dimension> builders set.
+const fullTemplate = `// Code generated by aws-mocker. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+	"context"
+	{{if .ClientDefault}}"fmt"
+	{{end}}{{range .Middlewares}}"{{.Path}}"
+	{{end}}{{if .EmitVerification}}
+	"github.com/dramich/aws-mocker/pkg/mock/verify"{{end}}{{if .PartitionBy}}
+	"github.com/dramich/aws-mocker/pkg/mock/partition"{{end}}
+)
+{{range .Middlewares}}{{$pkg := .}}
+// {{ToTitle $pkg.Name}}Client is a generated mock of the {{$pkg.Name}} service client.
+type {{ToTitle $pkg.Name}}Client struct {
+{{range .FuncSigs}}
+	{{.FuncName}}Responses []struct {
+		Match func(*{{$pkg.Name}}.{{.Input}}) bool
+		Out   *{{$pkg.Name}}.{{.Return}}
+		Err   error
+	}
+{{if $.EmitVerification}}	{{.FuncName}}Calls verify.Recorder[*{{$pkg.Name}}.{{.Input}}]
+{{end}}{{if $.PartitionBy}}	{{.FuncName}}Partitions map[string]struct {
+		Out *{{$pkg.Name}}.{{.Return}}
+		Err error
+	}
+{{end}}{{end}}}
+
+// New{{ToTitle $pkg.Name}}Client builds a {{ToTitle $pkg.Name}}Client{{if $.FixturesDir}}, loading fixtures from
+// "{{$.FixturesDir}}"{{end}}.
+func New{{ToTitle $pkg.Name}}Client() *{{ToTitle $pkg.Name}}Client {
+	c := &{{ToTitle $pkg.Name}}Client{}
+{{if $.FixturesDir}}	if err := c.Load{{ToTitle $pkg.Name}}Fixtures("{{$.FixturesDir}}"); err != nil {
+		panic(err)
+	}
+{{end}}	return c
+}
+{{range .FuncSigs}}
+// {{.FuncName}} returns the first matcher response whose Match reports true for params,
+// then the first FIFO-queued response (Match == nil) in append order.{{if $.ClientDefault}} Panics
+// if neither is configured.{{else}} Returns a zero value if neither is configured.{{end}}
+func (c *{{ToTitle $pkg.Name}}Client) {{.FuncName}}(ctx context.Context, params *{{$pkg.Name}}.{{.Input}}, optFns ...func(*{{$pkg.Name}}.Options)) (*{{$pkg.Name}}.{{.Return}}, error) {
+{{if $.EmitVerification}}	optFnsAny := make([]any, len(optFns))
+	for i, f := range optFns {
+		optFnsAny[i] = f
+	}
+	c.{{.FuncName}}Calls.Record(verify.NextSeq(), ctx, params, optFnsAny...)
+{{end}}{{if $.PartitionBy}}	if resp, ok := c.{{.FuncName}}Partitions[partition.KeyFor(ctx, []string{ {{range $.PartitionBy}}"{{.}}", {{end}} }).String()]; ok {
+		return resp.Out, resp.Err
+	}
+{{end}}	for i, r := range c.{{.FuncName}}Responses {
+		if r.Match != nil && r.Match(params) {
+			c.{{.FuncName}}Responses = append(c.{{.FuncName}}Responses[:i], c.{{.FuncName}}Responses[i+1:]...)
+			return r.Out, r.Err
+		}
+	}
+	for i, r := range c.{{.FuncName}}Responses {
+		if r.Match == nil {
+			c.{{.FuncName}}Responses = append(c.{{.FuncName}}Responses[:i], c.{{.FuncName}}Responses[i+1:]...)
+			return r.Out, r.Err
+		}
+	}
+{{if $.ClientDefault}}	panic(fmt.Sprintf("{{ToTitle $pkg.Name}}Client.{{.FuncName}}: no response configured"))
+{{else}}	var zero {{$pkg.Name}}.{{.Return}}
+	return &zero, nil
+{{end}}}
+{{end}}{{end}}`
@@ -0,0 +1,136 @@
+// Package verify is the runtime support for mocks generated with
+// mock.Options.EmitVerification: thread-safe call recorders, generic argument
+// matchers, and the Result type behind the generated
+// Verify<Service>(t, client).<Operation>(matcher).Times(n) assertion API, in the
+// style of pegomock.
+package verify
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// Matcher reports whether a recorded argument satisfies some condition.
+type Matcher[T any] func(T) bool
+
+// Any matches any value of T.
+func Any[T any]() Matcher[T] {
+	return func(T) bool { return true }
+}
+
+// Eq matches values deeply equal to v.
+func Eq[T any](v T) Matcher[T] {
+	return func(got T) bool { return reflect.DeepEqual(got, v) }
+}
+
+// Custom matches values for which f reports true.
+func Custom[T any](f func(T) bool) Matcher[T] {
+	return Matcher[T](f)
+}
+
+var seq atomic.Uint64
+
+// NextSeq returns a call sequence number, shared across every recorder in the
+// process, so InOrder can compare calls made against different operations or
+// different services.
+func NextSeq() uint64 {
+	return seq.Add(1)
+}
+
+// Call is a single recorded invocation of an operation, capturing the full
+// (ctx, input, optFns) tuple a generated stub was called with.
+type Call[T any] struct {
+	Seq    uint64
+	Ctx    context.Context
+	Input  T
+	OptFns []any
+}
+
+// Recorder records calls to a single operation, in call order. It is safe for
+// concurrent use, since generated clients may be exercised from multiple goroutines.
+type Recorder[T any] struct {
+	mu    sync.Mutex
+	calls []Call[T]
+}
+
+// Record appends a call. Generated stubs call this once per invocation with the
+// sequence number from NextSeq and every argument they were called with; optFns is
+// passed as []any since each operation's optFns type (e.g. ...func(*dynamodb.Options))
+// is specific to that operation's service package, which this generic package can't
+// reference.
+func (r *Recorder[T]) Record(seq uint64, ctx context.Context, in T, optFns ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call[T]{Seq: seq, Ctx: ctx, Input: in, OptFns: optFns})
+}
+
+// Matching returns the recorded calls, in order, whose input satisfies m.
+func (r *Recorder[T]) Matching(m Matcher[T]) []Call[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []Call[T]
+	for _, c := range r.calls {
+		if m(c.Input) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Result is returned by a Verify(...).<Operation>(matcher) call. It asserts call
+// counts via Times/Once and carries the matched calls' sequence numbers so they can
+// be threaded into InOrder.
+type Result struct {
+	t    testing.TB
+	op   string
+	seqs []uint64
+}
+
+// NewResult builds a Result from the calls matched for op. Generated verifiers call
+// this directly; it is not expected to be called from test code.
+func NewResult[T any](t testing.TB, op string, calls []Call[T]) Result {
+	seqs := make([]uint64, len(calls))
+	for i, c := range calls {
+		seqs[i] = c.Seq
+	}
+	return Result{t: t, op: op, seqs: seqs}
+}
+
+// Times asserts that exactly n calls matched.
+func (r Result) Times(n int) Result {
+	r.t.Helper()
+	if len(r.seqs) != n {
+		r.t.Errorf("%s: expected %d matching call(s), got %d", r.op, n, len(r.seqs))
+	}
+	return r
+}
+
+// Once asserts that exactly one call matched.
+func (r Result) Once() Result {
+	r.t.Helper()
+	return r.Times(1)
+}
+
+// InOrder asserts that each Result's matched calls happened after the previous
+// Result's: the last sequence number of results[i-1] must be less than the first
+// sequence number of results[i]. Results with no matched calls are skipped, so
+// InOrder can be used to check a subsequence across services.
+func InOrder(t testing.TB, results ...Result) {
+	t.Helper()
+
+	var prev Result
+	havePrev := false
+	for _, r := range results {
+		if len(r.seqs) == 0 {
+			continue
+		}
+		if havePrev && prev.seqs[len(prev.seqs)-1] >= r.seqs[0] {
+			t.Errorf("expected %s to happen before %s", prev.op, r.op)
+		}
+		prev, havePrev = r, true
+	}
+}
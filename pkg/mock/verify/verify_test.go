@@ -0,0 +1,85 @@
+package verify
+
+import (
+	"context"
+	"testing"
+)
+
+type fakeT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *fakeT) Helper()                          {}
+func (t *fakeT) Errorf(format string, args ...any) { t.errors = append(t.errors, format) }
+
+func TestMatchers(t *testing.T) {
+	if !Any[int]()(42) {
+		t.Error("Any should match everything")
+	}
+
+	if !Eq(5)(5) || Eq(5)(6) {
+		t.Error("Eq should match only deeply equal values")
+	}
+
+	isEven := Custom(func(n int) bool { return n%2 == 0 })
+	if !isEven(4) || isEven(3) {
+		t.Error("Custom should defer to the given func")
+	}
+}
+
+func TestRecorderMatching(t *testing.T) {
+	var r Recorder[string]
+	r.Record(NextSeq(), context.Background(), "a")
+	r.Record(NextSeq(), context.Background(), "b")
+	r.Record(NextSeq(), context.Background(), "a")
+
+	matches := r.Matching(Eq("a"))
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].Input != "a" || matches[1].Input != "a" {
+		t.Errorf("unexpected matched inputs: %+v", matches)
+	}
+}
+
+func TestResultTimes(t *testing.T) {
+	var r Recorder[string]
+	r.Record(NextSeq(), context.Background(), "a")
+
+	ft := &fakeT{}
+	NewResult(ft, "op", r.Matching(Any[string]())).Times(1)
+	if len(ft.errors) != 0 {
+		t.Errorf("expected no errors, got %v", ft.errors)
+	}
+
+	ft = &fakeT{}
+	NewResult(ft, "op", r.Matching(Any[string]())).Times(2)
+	if len(ft.errors) != 1 {
+		t.Errorf("expected one error for a count mismatch, got %v", ft.errors)
+	}
+}
+
+func TestInOrder(t *testing.T) {
+	var first, second Recorder[string]
+	first.Record(NextSeq(), context.Background(), "a")
+	second.Record(NextSeq(), context.Background(), "b")
+
+	ft := &fakeT{}
+	InOrder(ft,
+		NewResult(ft, "first", first.Matching(Any[string]())),
+		NewResult(ft, "second", second.Matching(Any[string]())),
+	)
+	if len(ft.errors) != 0 {
+		t.Errorf("expected calls recorded in order to pass, got %v", ft.errors)
+	}
+
+	ft = &fakeT{}
+	InOrder(ft,
+		NewResult(ft, "second", second.Matching(Any[string]())),
+		NewResult(ft, "first", first.Matching(Any[string]())),
+	)
+	if len(ft.errors) != 1 {
+		t.Errorf("expected out-of-order calls to fail, got %v", ft.errors)
+	}
+}
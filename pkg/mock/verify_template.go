@@ -0,0 +1,77 @@
+package mock
+
+import (
+	"bytes"
+	"html/template"
+	log "log/slog"
+	"os"
+
+	"golang.org/x/tools/imports"
+)
+
+// verifyTemplate emits a companion "<PackageName>_verify.go" file giving each
+// generated "<Service>Client" a fluent
+// Verify<Service>(t, client).<Operation>(matcher).Times(n) assertion API, backed by the call recorders in pkg/mock/verify. Clients generated
+// with EmitVerification record every (ctx, input, optFns) call so tests can assert
+// on it after the fact instead of wiring up expectations up front.
+const verifyTemplate = `// Code generated by aws-mocker. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+	"testing"
+
+	"github.com/dramich/aws-mocker/pkg/mock/verify"
+)
+{{range .Middlewares}}{{$pkg := .}}
+// {{ToTitle $pkg.Name}}Verifier is a fluent assertion API over a {{ToTitle $pkg.Name}}Client's
+// recorded calls.
+type {{ToTitle $pkg.Name}}Verifier struct {
+	t testing.TB
+	c *{{ToTitle $pkg.Name}}Client
+}
+
+// Verify{{ToTitle $pkg.Name}} returns a verifier over c's recorded calls, reporting assertion
+// failures on t.
+func Verify{{ToTitle $pkg.Name}}(t testing.TB, c *{{ToTitle $pkg.Name}}Client) {{ToTitle $pkg.Name}}Verifier {
+	t.Helper()
+	return {{ToTitle $pkg.Name}}Verifier{t: t, c: c}
+}
+{{range .FuncSigs}}
+// {{.FuncName}} asserts against recorded calls to {{.FuncName}} whose input matches m.
+func (v {{ToTitle $pkg.Name}}Verifier) {{.FuncName}}(m verify.Matcher[*{{$pkg.Name}}.{{.Input}}]) verify.Result {
+	v.t.Helper()
+	return verify.NewResult(v.t, "{{ToTitle $pkg.Name}}.{{.FuncName}}", v.c.{{.FuncName}}Calls.Matching(m))
+}
+{{end}}{{end}}`
+
+// writeVerification renders verifyTemplate and writes it out as a companion file
+// alongside the main generated mock.
+func writeVerification(opts *Options, data TemplateData) error {
+	tmpl, err := template.New("verify").Funcs(templateFuncs()).Parse(verifyTemplate)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, data); err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	formatted, err := imports.Process("filename", buf.Bytes(), &imports.Options{
+		TabWidth:  4,
+		TabIndent: true,
+		Comments:  true,
+		Fragment:  true,
+	})
+	if err != nil {
+		log.Debug(buf.String())
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	_, err = companionWriter(opts, "_verify").Write(formatted)
+	return err
+}
@@ -0,0 +1,108 @@
+// Package record provides an SDK v2 middleware that turns real AWS traffic into
+// aws-mocker fixtures, so a program can be run once against a live account and
+// replayed forever after with mock.Options.FixturesDir (or regenerated wholesale
+// with Options.ReplayFixtures).
+package record
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	log "log/slog"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	smithymiddleware "github.com/aws/smithy-go/middleware"
+)
+
+// WithRecorder returns a copy of cfg with a middleware appended to every client
+// built from it that records each successful call's input and output under
+// "<dir>/<service>/<Operation>/<hash>.json", where hash is derived from the
+// serialized input so repeat calls with identical arguments overwrite the same
+// recording instead of accumulating duplicates.
+//
+// The output is written in the same AWS SDK v2 JSON shape mock.Options.FixturesDir
+// already expects, so it can be replayed as-is; the input is written alongside it,
+// under the same hash, as "<hash>.input.json", purely so a recording can be traced
+// back to the call that produced it.
+func WithRecorder(cfg aws.Config, dir string) aws.Config {
+	cfg.APIOptions = append(cfg.APIOptions, func(stack *smithymiddleware.Stack) error {
+		// One recorder instance per stack build (i.e. per call), registered on both
+		// steps: the unserialized params are only available at Initialize, but
+		// whether the call succeeded, and its output, are only known at Deserialize.
+		r := &recorder{dir: dir}
+		if err := stack.Initialize.Add(r, smithymiddleware.Before); err != nil {
+			return err
+		}
+		return stack.Deserialize.Add(r, smithymiddleware.After)
+	})
+	return cfg
+}
+
+// recorder captures a call's params at the Initialize step, then writes them out
+// alongside its result once the Deserialize step confirms it succeeded.
+type recorder struct {
+	dir    string
+	params any
+}
+
+func (*recorder) ID() string { return "aws-mocker.Recorder" }
+
+func (r *recorder) HandleInitialize(
+	ctx context.Context, in smithymiddleware.InitializeInput, next smithymiddleware.InitializeHandler,
+) (smithymiddleware.InitializeOutput, smithymiddleware.Metadata, error) {
+	r.params = in.Parameters
+	return next.HandleInitialize(ctx, in)
+}
+
+func (r *recorder) HandleDeserialize(
+	ctx context.Context, in smithymiddleware.DeserializeInput, next smithymiddleware.DeserializeHandler,
+) (smithymiddleware.DeserializeOutput, smithymiddleware.Metadata, error) {
+	out, metadata, err := next.HandleDeserialize(ctx, in)
+	if err != nil {
+		// Only successful calls make useful canned responses.
+		return out, metadata, err
+	}
+
+	if writeErr := r.write(ctx, r.params, out.Result); writeErr != nil {
+		// A failure to record a fixture should never fail the caller's real request.
+		log.Error("aws-mocker: failed to record fixture", "error", writeErr)
+	}
+
+	return out, metadata, err
+}
+
+// write serializes params and result and drops them next to each other under a
+// shared hash of the input, in dir/<service>/<Operation>/.
+func (r *recorder) write(ctx context.Context, params, result any) error {
+	input, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	output, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(input)
+	hash := hex.EncodeToString(sum[:])[:16]
+
+	// The service ID (e.g. "DynamoDB") is lowercased and stripped of spaces to give
+	// the service's Go package name (e.g. "dynamodb"), the directory name the
+	// generated fixture loader and replay mode read from.
+	service := strings.ReplaceAll(strings.ToLower(awsmiddleware.GetServiceID(ctx)), " ", "")
+	dir := path.Join(r.dir, service, awsmiddleware.GetOperationName(ctx))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path.Join(dir, hash+".json"), output, 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(path.Join(dir, hash+".input.json"), input, 0o644)
+}